@@ -0,0 +1,50 @@
+package viesparser
+
+import "testing"
+
+func TestFormatAddressRoundTrip(t *testing.T) {
+	tests := []struct {
+		countryCode string
+		address     ParsedAddress
+	}{
+		{cz, ParsedAddress{Street: "Wenceslas Square 1", City: "Praha 4", Zip: "120 00"}},
+		{sk, ParsedAddress{Street: "Hlavna 1", City: "Bratislava", Zip: "811 01"}},
+		{sk, ParsedAddress{Street: "Hlavna 1", City: "Petržalka", Zip: "851 01"}},
+		{nl, ParsedAddress{Street: "Damrak 1", City: "Amsterdam", Zip: "1012 LG"}},
+		{fr, ParsedAddress{Street: "Rue de la Paix 1", City: "Le Havre", Zip: "76600"}},
+		{si, ParsedAddress{Street: "Dunajska 1", City: "Ljubljana", Zip: "1000"}},
+		{hr, ParsedAddress{Street: "Ilica 1", City: "Zagreb", Zip: "10000"}},
+		{be, ParsedAddress{Street: "Rue Neuve 1", City: "Bruxelles", Zip: "1000"}},
+		{pt, ParsedAddress{Street: "Rua Augusta 1", City: "Lisboa", Zip: "1100-048"}},
+		{it, ParsedAddress{Street: "Via Roma 1", City: "Milano", Zip: "20100"}},
+		{fi, ParsedAddress{Street: "Mannerheimintie 1", City: "Helsinki", Zip: "00100"}},
+		{ro, ParsedAddress{Street: "Calea Victoriei 1", City: "Bucuresti", Zip: "010061"}},
+		{at, ParsedAddress{Street: "Stephansplatz 1", City: "Wien", Zip: "1010"}},
+		{pl, ParsedAddress{Street: "Marszalkowska 1", City: "Warszawa", Zip: "00-001"}},
+		{el, ParsedAddress{Street: "Patision 1", City: "Athina", Zip: "104 34"}},
+		{dk, ParsedAddress{Street: "Stroget 1", City: "Kobenhavn", Zip: "1000"}},
+		{ee, ParsedAddress{Street: "Viru 1", City: "Tallinn", Zip: "10111"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.countryCode+"/"+tt.address.City, func(t *testing.T) {
+			rendered, err := FormatAddress(tt.countryCode, tt.address)
+			if err != nil {
+				t.Fatalf("FormatAddress() error = %v", err)
+			}
+			parsed, err := ParseAddress(tt.countryCode, rendered)
+			if err != nil {
+				t.Fatalf("ParseAddress(%q) error = %v", rendered, err)
+			}
+			if parsed != tt.address {
+				t.Errorf("ParseAddress(FormatAddress(%+v)) = %+v, want %+v", tt.address, parsed, tt.address)
+			}
+		})
+	}
+}
+
+func TestFormatAddressUnsupportedCountryCode(t *testing.T) {
+	_, err := FormatAddress("XX", ParsedAddress{})
+	if err != ErrorUnsupportedCountryCode {
+		t.Errorf("FormatAddress() error = %v, want %v", err, ErrorUnsupportedCountryCode)
+	}
+}
@@ -0,0 +1,110 @@
+package viesparser
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"embed"
+	"strings"
+)
+
+//go:embed data/cities_*.txt.gz
+var cityGazetteerFS embed.FS
+
+// cityGazetteers maps a country code to its known, gazetteer-sourced city
+// names, used by SuggestCity to correct misspelled or abbreviated VIES
+// city names.
+var cityGazetteers = map[string][]string{}
+
+func init() {
+	entries, err := cityGazetteerFS.ReadDir("data")
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		countryCode := strings.TrimSuffix(strings.TrimPrefix(entry.Name(), "cities_"), ".txt.gz")
+		data, err := cityGazetteerFS.ReadFile("data/" + entry.Name())
+		if err != nil {
+			continue
+		}
+		names, err := decodeCityList(data)
+		if err != nil {
+			continue
+		}
+		cityGazetteers[countryCode] = names
+	}
+}
+
+// decodeCityList reads a gzip-compressed, newline-separated city list.
+func decodeCityList(data []byte) ([]string, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var names []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		if name := strings.TrimSpace(scanner.Text()); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, scanner.Err()
+}
+
+// RegisterCities replaces the known city gazetteer for countryCode, letting
+// callers swap in their own list instead of the bundled one.
+func RegisterCities(countryCode string, names []string) {
+	cityGazetteers[countryCode] = names
+}
+
+// SuggestCity returns the closest known city name to raw for countryCode
+// from its city gazetteer, together with the Levenshtein distance between
+// them. The third return value is false when no gazetteer is registered
+// for countryCode.
+func SuggestCity(countryCode, raw string) (string, int, bool) {
+	names := cityGazetteers[countryCode]
+	if len(names) == 0 {
+		return "", 0, false
+	}
+	rawLower := strings.ToLower(raw)
+	best, bestDistance := names[0], levenshteinDistance(rawLower, strings.ToLower(names[0]))
+	for _, name := range names[1:] {
+		if distance := levenshteinDistance(rawLower, strings.ToLower(name)); distance < bestDistance {
+			best, bestDistance = name, distance
+		}
+	}
+	return best, bestDistance, true
+}
+
+// levenshteinDistance returns the edit distance between a and b.
+func levenshteinDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(br)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
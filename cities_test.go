@@ -0,0 +1,84 @@
+package viesparser
+
+import "testing"
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"Praha", "Praha", 0},
+		{"Praha4", "Praha 4", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+	for _, tt := range tests {
+		if got := levenshteinDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshteinDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestCity(t *testing.T) {
+	suggestion, distance, ok := SuggestCity(cz, "PRAHA-4")
+	if !ok {
+		t.Fatal("SuggestCity() ok = false, want true")
+	}
+	if suggestion != "Praha 4" {
+		t.Errorf("SuggestCity() suggestion = %q, want %q", suggestion, "Praha 4")
+	}
+	if distance == 0 {
+		t.Errorf("SuggestCity() distance = 0, want > 0 for %q vs %q", "PRAHA-4", suggestion)
+	}
+
+	if _, _, ok := SuggestCity("XX", "Anytown"); ok {
+		t.Error("SuggestCity() ok = true for a country with no gazetteer, want false")
+	}
+}
+
+// TestSuggestCityKeepsDiacritics pins the bundled gazetteer entries to their
+// correctly-accented spelling, so a correctly spelled city is never
+// "normalized" into a de-accented one.
+func TestSuggestCityKeepsDiacritics(t *testing.T) {
+	tests := []struct {
+		countryCode string
+		city        string
+	}{
+		{sk, "Košice"},
+		{sk, "Žilina"},
+		{sk, "Trenčín"},
+		{sk, "Banská Bystrica"},
+		{cz, "Plzeň"},
+	}
+	for _, tt := range tests {
+		suggestion, distance, ok := SuggestCity(tt.countryCode, tt.city)
+		if !ok {
+			t.Fatalf("SuggestCity(%q, %q) ok = false, want true", tt.countryCode, tt.city)
+		}
+		if suggestion != tt.city || distance != 0 {
+			t.Errorf("SuggestCity(%q, %q) = (%q, %d), want (%q, 0)", tt.countryCode, tt.city, suggestion, distance, tt.city)
+		}
+	}
+}
+
+func TestParseAddressNormalizeCity(t *testing.T) {
+	t.Cleanup(func() { RegisterCities(cz, cityGazetteers[cz]) })
+
+	address := "Wenceslas Square 1\n120 00 PRAHA-4"
+
+	parsed, err := ParseAddress(cz, address)
+	if err != nil {
+		t.Fatalf("ParseAddress() error = %v", err)
+	}
+	if parsed.City != "PRAHA-4" {
+		t.Errorf("ParseAddress() without NormalizeCity City = %q, want %q", parsed.City, "PRAHA-4")
+	}
+
+	parsed, err = ParseAddress(cz, address, Config{NormalizeCity: true, CityDistanceThreshold: 3})
+	if err != nil {
+		t.Fatalf("ParseAddress() error = %v", err)
+	}
+	if parsed.City != "Praha 4" {
+		t.Errorf("ParseAddress() with NormalizeCity City = %q, want %q", parsed.City, "Praha 4")
+	}
+}
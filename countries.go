@@ -0,0 +1,203 @@
+package viesparser
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// CountryFormat describes how VIES renders addresses for a given country,
+// modelled on the address metadata used by CLDR and packages such as
+// bojanz/address. A layout is a template built from the tokens %N (name),
+// %S (street), %Z (zip) and %C (city); any other character in the template
+// is a literal separator (comma, space, newline, …) that anchors the
+// tokenizer when it walks the raw address. A line with no token is matched
+// literally, which is how e.g. a trailing country name is recognised.
+type CountryFormat struct {
+	// Formats maps the number of newlines found in the raw address to the
+	// candidate templates for that layout, tried in order until one
+	// matches. VIES does not always lay out addresses for the same
+	// country identically, so more than one template can be registered
+	// per newline count.
+	Formats map[int][]string
+	// RenderFormat is the template FormatAddress renders a ParsedAddress
+	// back into, chosen as the country's canonical layout.
+	RenderFormat string
+	// PostalCodeRegexp matches this country's postal code and anchors the
+	// split between the zip and city tokens on a shared line.
+	PostalCodeRegexp *regexp.Regexp
+	// NormalizeCity, when set, is applied to the extracted city before it
+	// is returned, e.g. to strip a district prefix.
+	NormalizeCity func(string) string
+	// DenormalizeCity, when set, is the inverse of NormalizeCity, applied
+	// by FormatAddress before the city is rendered, e.g. to reinsert a
+	// district prefix NormalizeCity strips.
+	DenormalizeCity func(string) string
+}
+
+// bratislavaKosiceDistricts lists the city districts ("mestská časť") of
+// Bratislava and Košice whose VIES-reported city name is prefixed with
+// "mestská časť " (or its abbreviation "m. č. ").
+var bratislavaKosiceDistricts = []string{
+	"Petržalka", "Ružinov", "Dúbravka", "Karlova Ves", "Staré Mesto",
+	"Nové Mesto", "Vajnory", "Rača", "Vrakuňa", "Podunajské Biskupice",
+	"Juh", "Sever", "Západ", "Džungľa",
+}
+
+var countryFormats = map[string]CountryFormat{
+	cz: {
+		Formats: map[int][]string{
+			1: {"%S\n%Z %C"},
+			2: {"%S\n%C\n%Z"},
+		},
+		RenderFormat:     "%S\n%Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{3} ?\d{2}`),
+	},
+	sk: {
+		Formats: map[int][]string{
+			1: {"%S\n%Z %C", "%Z %C\nSlovensko"},
+			2: {"%S\n%N\n%Z %C"},
+		},
+		RenderFormat:     "%S\n%Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{3} ?\d{2}`),
+		NormalizeCity: func(city string) string {
+			city = strings.Replace(city, "mestská časť ", "", 1)
+			city = strings.Replace(city, "m. č. ", "", 1)
+			return city
+		},
+		DenormalizeCity: func(city string) string {
+			if slices.Contains(bratislavaKosiceDistricts, city) {
+				return "mestská časť " + city
+			}
+			return city
+		},
+	},
+	nl: {
+		Formats:          map[int][]string{1: {"%S\n%Z %C"}},
+		RenderFormat:     "%S\n%Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{4} ?[A-Z]{2}`),
+	},
+	be: {
+		Formats:          map[int][]string{1: {"%S\n%Z %C"}},
+		RenderFormat:     "%S\n%Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{4}`),
+	},
+	fr: {
+		Formats:          map[int][]string{1: {"%S\n%Z %C"}},
+		RenderFormat:     "%S\n%Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{5}`),
+	},
+	pt: {
+		Formats:          map[int][]string{1: {"%S\n%Z %C"}},
+		RenderFormat:     "%S\n%Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{4}-\d{3}`),
+	},
+	it: {
+		Formats:          map[int][]string{1: {"%S\n%Z %C"}},
+		RenderFormat:     "%S\n%Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{5}`),
+	},
+	fi: {
+		Formats:          map[int][]string{1: {"%S\n%Z %C"}},
+		RenderFormat:     "%S\n%Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{5}`),
+	},
+	ro: {
+		Formats:          map[int][]string{1: {"%S\n%Z %C"}},
+		RenderFormat:     "%S\n%Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{6}`),
+	},
+	si: {
+		Formats:          map[int][]string{0: {"%S, %Z %C"}},
+		RenderFormat:     "%S, %Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{4}`),
+	},
+	at: {
+		Formats:          map[int][]string{1: {"%S\n%Z %C"}},
+		RenderFormat:     "%S\n%Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{4}`),
+	},
+	pl: {
+		Formats:          map[int][]string{1: {"%S\n%Z %C"}},
+		RenderFormat:     "%S\n%Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{2}-\d{3}`),
+	},
+	hr: {
+		Formats:          map[int][]string{0: {"%S, %Z %C"}},
+		RenderFormat:     "%S, %Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{5}`),
+	},
+	el: {
+		Formats:          map[int][]string{1: {"%S\n%Z %C"}},
+		RenderFormat:     "%S\n%Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{3} ?\d{2}`),
+	},
+	dk: {
+		Formats:          map[int][]string{1: {"%S\n%Z %C"}},
+		RenderFormat:     "%S\n%Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{4}`),
+	},
+	ee: {
+		Formats:          map[int][]string{1: {"%S\n%Z %C"}},
+		RenderFormat:     "%S\n%Z %C",
+		PostalCodeRegexp: regexp.MustCompile(`\d{5}`),
+	},
+}
+
+// GetCountryFormat returns the registered address format for countryCode.
+// The second return value is false when no format has been registered,
+// mirroring the ok-idiom used elsewhere for map lookups.
+func GetCountryFormat(countryCode string) (CountryFormat, bool) {
+	format, ok := countryFormats[countryCode]
+	return format, ok
+}
+
+// RegisterCountryFormat adds or overrides the address format used for
+// countryCode, letting callers support additional countries or adjust an
+// existing layout without patching this package.
+func RegisterCountryFormat(countryCode string, format CountryFormat) {
+	countryFormats[countryCode] = format
+}
+
+// ValidatePostalCode reports whether zip matches the postal-code format
+// registered for countryCode, following the same check bojanz/address
+// performs via Format.CheckPostalCode. It returns ErrorUnsupportedCountryCode
+// when no format is registered, and ErrorInvalidPostalCode when zip doesn't
+// match. Countries with no registered postal-code regexp are not validated.
+func ValidatePostalCode(countryCode, zip string) error {
+	format, ok := GetCountryFormat(countryCode)
+	if !ok {
+		return ErrorUnsupportedCountryCode
+	}
+	if format.PostalCodeRegexp == nil {
+		return nil
+	}
+	zip = strings.TrimSpace(zip)
+	loc := format.PostalCodeRegexp.FindStringIndex(zip)
+	if loc == nil || loc[0] != 0 || loc[1] != len(zip) {
+		return ErrorInvalidPostalCode
+	}
+	return nil
+}
+
+// FormatAddress renders a into the layout VIES/CLDR expects for
+// countryCode, the reverse of ParseAddress. It closes the loop for callers
+// that parse, edit and need to re-serialize an address, e.g. for an
+// invoice or an ERP round-trip.
+func FormatAddress(countryCode string, a ParsedAddress) (string, error) {
+	format, ok := GetCountryFormat(countryCode)
+	if !ok {
+		return "", ErrorUnsupportedCountryCode
+	}
+	if format.RenderFormat == "" {
+		return "", ErrorInvalidOption
+	}
+	city := a.City
+	if format.DenormalizeCity != nil {
+		city = format.DenormalizeCity(city)
+	}
+	tokens := map[string]string{"%S": a.Street, "%Z": a.Zip, "%C": city}
+	return templateTokenPattern.ReplaceAllStringFunc(format.RenderFormat, func(token string) string {
+		return tokens[token]
+	}), nil
+}
@@ -0,0 +1,96 @@
+package viesparser
+
+import "testing"
+
+func TestValidatePostalCode(t *testing.T) {
+	tests := []struct {
+		countryCode string
+		zip         string
+		wantErr     error
+	}{
+		{cz, "120 00", nil},
+		{cz, "12000", nil},
+		{cz, "1200", ErrorInvalidPostalCode},
+		{nl, "5211 CD", nil},
+		{nl, "5211", ErrorInvalidPostalCode},
+		{pl, "00-001", nil},
+		{pl, "00001", ErrorInvalidPostalCode},
+		{"XX", "0000", ErrorUnsupportedCountryCode},
+	}
+	for _, tt := range tests {
+		if err := ValidatePostalCode(tt.countryCode, tt.zip); err != tt.wantErr {
+			t.Errorf("ValidatePostalCode(%q, %q) = %v, want %v", tt.countryCode, tt.zip, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseAddressSplitsMultiWordCityOnPostalCodeAnchor(t *testing.T) {
+	tests := []struct {
+		name        string
+		countryCode string
+		address     string
+		wantCity    string
+		wantZip     string
+	}{
+		{"NL city with embedded hyphen", nl, "Wilhelminaplein 1\n5211 CD 's-Hertogenbosch", "'s-Hertogenbosch", "5211 CD"},
+		{"FR two-word city", fr, "Rue de la Paix 1\n76600 Le Havre", "Le Havre", "76600"},
+		{"CZ two-word city", cz, "Wenceslas Square 1\n120 00 Praha 4", "Praha 4", "120 00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseAddress(tt.countryCode, tt.address)
+			if err != nil {
+				t.Fatalf("ParseAddress() error = %v", err)
+			}
+			if parsed.City != tt.wantCity || parsed.Zip != tt.wantZip {
+				t.Errorf("ParseAddress() = %+v, want City=%q Zip=%q", parsed, tt.wantCity, tt.wantZip)
+			}
+		})
+	}
+}
+
+func TestParseAddressMultilineTemplates(t *testing.T) {
+	tests := []struct {
+		name        string
+		countryCode string
+		address     string
+		want        ParsedAddress
+	}{
+		{
+			"CZ 2-newline with trailing country name",
+			cz,
+			"Wenceslas Square 1\nPraha\n120 00 Czech Republic",
+			ParsedAddress{Street: "Wenceslas Square 1", City: "Praha", Zip: "120 00"},
+		},
+		{
+			"SK 2-newline with name line",
+			sk,
+			"Hlavna 1\nFirma s.r.o.\n811 01 Bratislava",
+			ParsedAddress{Street: "Hlavna 1", City: "Bratislava", Zip: "811 01"},
+		},
+		{
+			"SK Slovensko trailing line",
+			sk,
+			"811 01 Bratislava\nSlovensko",
+			ParsedAddress{Street: "", City: "Bratislava", Zip: "811 01"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseAddress(tt.countryCode, tt.address)
+			if err != nil {
+				t.Fatalf("ParseAddress() error = %v", err)
+			}
+			if parsed != tt.want {
+				t.Errorf("ParseAddress() = %+v, want %+v", parsed, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAddressInvalidPostalCode(t *testing.T) {
+	_, err := ParseAddress(nl, "Wilhelminaplein 1\nCD Amsterdam")
+	if err != ErrorInvalidPostalCode {
+		t.Errorf("ParseAddress() error = %v, want %v", err, ErrorInvalidPostalCode)
+	}
+}
@@ -4,6 +4,7 @@ import "errors"
 
 var (
 	ErrorInvalidOption          = errors.New("invalid option")
+	ErrorInvalidPostalCode      = errors.New("invalid postal code")
 	ErrorMissingAddress         = errors.New("missing address")
 	ErrorMissingCountryCode     = errors.New("missing country code")
 	ErrorUnsupportedCountryCode = errors.New("unsupported country code")
@@ -0,0 +1,20 @@
+package viesparser
+
+// Parser parses a raw address for countryCode into a ParsedAddress. It lets
+// callers plug in an alternative implementation, such as the libpostal
+// adapter in this package, for countries the built-in CountryFormat table
+// doesn't cover.
+type Parser interface {
+	Parse(countryCode, address string) (ParsedAddress, error)
+}
+
+// fallbackParser is consulted by ParseAddress when the built-in parser
+// can't handle an address. It is nil until SetFallbackParser is called.
+var fallbackParser Parser
+
+// SetFallbackParser registers p as the parser ParseAddress falls back to
+// when the built-in country-specific parser returns ErrorUnsupportedCountryCode
+// or ErrorInvalidOption. Passing nil disables the fallback.
+func SetFallbackParser(p Parser) {
+	fallbackParser = p
+}
@@ -0,0 +1,37 @@
+package viesparser
+
+import "testing"
+
+type stubParser struct {
+	parsed ParsedAddress
+	err    error
+}
+
+func (p stubParser) Parse(countryCode, address string) (ParsedAddress, error) {
+	return p.parsed, p.err
+}
+
+func TestParseAddressFallsBackToRegisteredParser(t *testing.T) {
+	t.Cleanup(func() { SetFallbackParser(nil) })
+
+	want := ParsedAddress{Street: "Unter den Linden 1", City: "Berlin", Zip: "10117"}
+	SetFallbackParser(stubParser{parsed: want})
+
+	parsed, err := ParseAddress("DE", "Unter den Linden 1\n10117 Berlin")
+	if err != nil {
+		t.Fatalf("ParseAddress() error = %v", err)
+	}
+	if parsed != want {
+		t.Errorf("ParseAddress() = %+v, want %+v", parsed, want)
+	}
+}
+
+func TestParseAddressDoesNotFallBackOnOtherErrors(t *testing.T) {
+	t.Cleanup(func() { SetFallbackParser(nil) })
+	SetFallbackParser(stubParser{parsed: ParsedAddress{Street: "should not be used"}})
+
+	_, err := ParseAddress(nl, "Wilhelminaplein 1\nCD Amsterdam")
+	if err != ErrorInvalidPostalCode {
+		t.Errorf("ParseAddress() error = %v, want %v", err, ErrorInvalidPostalCode)
+	}
+}
@@ -0,0 +1,66 @@
+package viesparser
+
+import "regexp"
+
+// greekRule is a single Greek-to-Latin transliteration rule.
+type greekRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// greekExpressions lists the transliteration rules in application order.
+// Digraphs (μπ, ντ, αυ, …) must be matched before the single letters that
+// compose them, so digraph rules come first and single-letter rules last.
+var greekExpressions = []greekRule{
+	{regexp.MustCompile(`[αΑ][ιίΙΊ]`), "e"},
+	{regexp.MustCompile(`[οΟΕε][ιίΙΊ]`), "i"},
+	{regexp.MustCompile(`[αΑ][υύΥΎ]([θΘκΚξΞπΠσςΣτΤφΦχΧψΨ]|\s|$)`), "af$1"},
+	{regexp.MustCompile(`[αΑ][υύΥΎ]`), "av"},
+	{regexp.MustCompile(`[εΕ][υύΥΎ]([θΘκΚξΞπΠσςΣτΤφΦχΧψΨ]|\s|$)`), "ef$1"},
+	{regexp.MustCompile(`[εΕ][υύΥΎ]`), "ev"},
+	{regexp.MustCompile(`[οΟ][υύΥΎ]`), "ou"},
+	{regexp.MustCompile(`(^|\s)[μΜ][πΠ]`), "${1}b"},
+	{regexp.MustCompile(`[μΜ][πΠ](\s|$)`), "b$1"},
+	{regexp.MustCompile(`[μΜ][πΠ]`), "mp"},
+	{regexp.MustCompile(`[νΝ][τΤ]`), "nt"},
+	{regexp.MustCompile(`[τΤ][σΣ]`), "ts"},
+	{regexp.MustCompile(`[τΤ][ζΖ]`), "tz"},
+	{regexp.MustCompile(`[γΓ][γΓ]`), "ng"},
+	{regexp.MustCompile(`[γΓ][κΚ]`), "gk"},
+	{regexp.MustCompile(`[ηΗ][υΥ]([θΘκΚξΞπΠσςΣτΤφΦχΧψΨ]|\s|$)`), "if$1"},
+	{regexp.MustCompile(`[ηΗ][υΥ]`), "iu"},
+	{regexp.MustCompile(`[θΘ]`), "th"},
+	{regexp.MustCompile(`[χΧ]`), "ch"},
+	{regexp.MustCompile(`[ψΨ]`), "ps"},
+	{regexp.MustCompile(`[αάΑΆ]`), "a"},
+	{regexp.MustCompile(`[βΒ]`), "v"},
+	{regexp.MustCompile(`[γΓ]`), "g"},
+	{regexp.MustCompile(`[δΔ]`), "d"},
+	{regexp.MustCompile(`[εέΕΈ]`), "e"},
+	{regexp.MustCompile(`[ζΖ]`), "z"},
+	{regexp.MustCompile(`[ηήΗΉ]`), "i"},
+	{regexp.MustCompile(`[ιίϊΙΊΪ]`), "i"},
+	{regexp.MustCompile(`[κΚ]`), "k"},
+	{regexp.MustCompile(`[λΛ]`), "l"},
+	{regexp.MustCompile(`[μΜ]`), "m"},
+	{regexp.MustCompile(`[νΝ]`), "n"},
+	{regexp.MustCompile(`[ξΞ]`), "x"},
+	{regexp.MustCompile(`[οόΟΌ]`), "o"},
+	{regexp.MustCompile(`[πΠ]`), "p"},
+	{regexp.MustCompile(`[ρΡ]`), "r"},
+	{regexp.MustCompile(`[σςΣ]`), "s"},
+	{regexp.MustCompile(`[τΤ]`), "t"},
+	{regexp.MustCompile(`[υύϋΥΎΫ]`), "i"},
+	{regexp.MustCompile(`(?i)[φΦ]`), "f"},
+	{regexp.MustCompile(`(?i)[ωώ]`), "o"},
+}
+
+// TransliterateGreek converts Greek-script text to its Latin approximation,
+// applying the digraph rules before the single-letter rules so that e.g.
+// "μπ" is rewritten as a whole instead of as separate μ and π letters.
+func TransliterateGreek(s string) string {
+	for _, rule := range greekExpressions {
+		s = rule.pattern.ReplaceAllString(s, rule.replacement)
+	}
+	return s
+}
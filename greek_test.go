@@ -0,0 +1,46 @@
+package viesparser
+
+import "testing"
+
+func TestTransliterateGreek(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"mp at word start becomes b", "μπαρ", "bar"},
+		{"mp in the middle becomes mp", "καμπος", "kampos"},
+		{"mp at word end becomes b", "καμπ", "kab"},
+		{"au before a voiceless consonant becomes af", "αυτος", "aftos"},
+		{"au elsewhere becomes av", "αυλη", "avli"},
+		{"final sigma becomes s", "κοσμος", "kosmos"},
+		{"capital alpha with tonos becomes a", "Άγιος", "agios"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TransliterateGreek(tt.in); got != tt.want {
+				t.Errorf("TransliterateGreek(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAddressGreek(t *testing.T) {
+	address := "Πατησιων 1\n10434 Αθηνα"
+
+	parsed, err := ParseAddress(el, address)
+	if err != nil {
+		t.Fatalf("ParseAddress() error = %v", err)
+	}
+	if parsed.Street == "Πατησιων 1" || parsed.City == "Αθηνα" {
+		t.Errorf("ParseAddress() = %+v, want transliterated street/city", parsed)
+	}
+
+	parsed, err = ParseAddress(el, address, Config{IgnoreGreek: true})
+	if err != nil {
+		t.Fatalf("ParseAddress() error = %v", err)
+	}
+	if parsed.Street != "Πατησιων 1" || parsed.City != "Αθηνα" {
+		t.Errorf("ParseAddress() with IgnoreGreek = %+v, want original Greek street/city", parsed)
+	}
+}
@@ -0,0 +1,69 @@
+//go:build libpostal
+
+package viesparser
+
+/*
+#cgo LDFLAGS: -lpostal
+#include <libpostal/libpostal.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"strings"
+	"unsafe"
+)
+
+// LibpostalParser is a Parser backed by libpostal's address parser, for
+// countries the built-in CountryFormat table doesn't cover. Callers must
+// call libpostal_setup and libpostal_setup_parser once at process start
+// (and the matching teardown functions at exit) before registering an
+// instance with SetFallbackParser.
+type LibpostalParser struct{}
+
+// Parse implements Parser using libpostal_parse_address. It maps libpostal's
+// labeled components to ParsedAddress: Street is house_number + road + unit,
+// City prefers city and falls back to suburb then city_district, and Zip is
+// postcode.
+func (LibpostalParser) Parse(countryCode, address string) (ParsedAddress, error) {
+	cAddress := C.CString(address)
+	defer C.free(unsafe.Pointer(cAddress))
+
+	options := C.libpostal_get_address_parser_default_options()
+	response := C.libpostal_parse_address(cAddress, options)
+	if response == nil {
+		return ParsedAddress{}, ErrorInvalidOption
+	}
+	defer C.libpostal_address_parser_response_destroy(response)
+
+	count := int(response.num_components)
+	labels := (*[1 << 20]*C.char)(unsafe.Pointer(response.labels))[:count:count]
+	values := (*[1 << 20]*C.char)(unsafe.Pointer(response.components))[:count:count]
+	components := make(map[string]string, count)
+	for i := 0; i < count; i++ {
+		components[C.GoString(labels[i])] = C.GoString(values[i])
+	}
+
+	city := components["city"]
+	if city == "" {
+		city = components["suburb"]
+	}
+	if city == "" {
+		city = components["city_district"]
+	}
+	return ParsedAddress{
+		Street: strings.TrimSpace(strings.Join(nonEmptyStrings(components["house_number"], components["road"], components["unit"]), " ")),
+		City:   city,
+		Zip:    components["postcode"],
+	}, nil
+}
+
+func nonEmptyStrings(values ...string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
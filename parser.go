@@ -14,6 +14,11 @@ type ParsedAddress struct {
 
 type Config struct {
 	IgnoreGreek bool
+	// NormalizeCity, when true, snaps the parsed city to the closest entry
+	// in the country's city gazetteer (see SuggestCity) whenever that
+	// entry is within CityDistanceThreshold edits of the raw value.
+	NormalizeCity         bool
+	CityDistanceThreshold int
 }
 
 const (
@@ -35,56 +40,32 @@ const (
 	ee = "EE"
 )
 
-var (
-	SupportedCountryCodes = []string{
-		cz, sk, nl, be, fr, pt, it, fi, ro, si, at, pl, hr, el, dk, ee,
-	}
-	greekExpressions = map[*regexp.Regexp]string{
-		regexp.MustCompile("/[αΑ][ιίΙΊ]/u"):                             "e",
-		regexp.MustCompile("/[οΟΕε][ιίΙΊ]/u"):                           "i",
-		regexp.MustCompile(`/[αΑ][υύΥΎ]([θΘκΚξΞπΠσςΣτTφΡχΧψΨ]|\s|$)/u`): "af$1",
-		regexp.MustCompile("/[αΑ][υύΥΎ]/u"):                             "av",
-		regexp.MustCompile(`/[εΕ][υύΥΎ]([θΘκΚξΞπΠσςΣτTφΡχΧψΨ]|\s|$)/u`): "ef$1",
-		regexp.MustCompile("/[εΕ][υύΥΎ]/u"):                             "ev",
-		regexp.MustCompile("/[οΟ][υύΥΎ]/u"):                             "ou",
-		regexp.MustCompile(`/(^|\s)[μΜ][πΠ]/u`):                         "$1b",
-		regexp.MustCompile(`/[μΜ][πΠ](\s|$)/u`):                         "b$1",
-		regexp.MustCompile(`/[μΜ][πΠ]/u`):                               "mp",
-		regexp.MustCompile(`/[νΝ][τΤ]/u`):                               "nt",
-		regexp.MustCompile(`/[τΤ][σΣ]/u`):                               "ts",
-		regexp.MustCompile(`/[τΤ][ζΖ]/u`):                               "tz",
-		regexp.MustCompile(`/[γΓ][γΓ]/u`):                               "ng",
-		regexp.MustCompile(`/[γΓ][κΚ]/u`):                               "gk",
-		regexp.MustCompile(`/[ηΗ][υΥ]([θΘκΚξΞπΠσςΣτTφΡχΧψΨ]|\s|$)/u`):   "if$1",
-		regexp.MustCompile(`/[ηΗ][υΥ]/u`):                               "iu",
-		regexp.MustCompile(`/[θΘ]/u`):                                   "th",
-		regexp.MustCompile(`/[χΧ]/u`):                                   "ch",
-		regexp.MustCompile(`/[ψΨ]/u`):                                   "ps",
-		regexp.MustCompile(`/[αά]/u`):                                   "a",
-		regexp.MustCompile(`/[βΒ]/u`):                                   "v",
-		regexp.MustCompile(`/[γΓ]/u`):                                   "g",
-		regexp.MustCompile(`/[δΔ]/u`):                                   "d",
-		regexp.MustCompile(`/[εέΕΈ]/u`):                                 "e",
-		regexp.MustCompile(`/[ζΖ]/u`):                                   "z",
-		regexp.MustCompile(`/[ηήΗΉ]/u`):                                 "i",
-		regexp.MustCompile(`/[ιίϊΙΊΪ]/u`):                               "i",
-		regexp.MustCompile(`/[κΚ]/u`):                                   "k",
-		regexp.MustCompile(`/[λΛ]/u`):                                   "l",
-		regexp.MustCompile(`/[μΜ]/u`):                                   "m",
-		regexp.MustCompile(`/[νΝ]/u`):                                   "n",
-		regexp.MustCompile(`/[ξΞ]/u`):                                   "x",
-		regexp.MustCompile(`/[οόΟΌ]/u`):                                 "o",
-		regexp.MustCompile(`/[πΠ]/u`):                                   "p",
-		regexp.MustCompile(`/[ρΡ]/u`):                                   "r",
-		regexp.MustCompile(`/[σςΣ]/u`):                                  "s",
-		regexp.MustCompile(`/[τΤ]/u`):                                   "t",
-		regexp.MustCompile(`/[υύϋΥΎΫ]/u`):                               "i",
-		regexp.MustCompile(`/[φΦ]/iu`):                                  "f",
-		regexp.MustCompile(`/[ωώ]/iu`):                                  "o",
-		regexp.MustCompile(`/[Α]/iu`):                                   "a",
+// templateTokenPattern matches a token placeholder (%N, %S, %Z, %C) in
+// a CountryFormat line template.
+var templateTokenPattern = regexp.MustCompile(`%[NSZC]`)
+
+// SupportedCountryCodes returns the country codes with a registered
+// CountryFormat, sorted for a stable result. It reflects additions and
+// overrides made via RegisterCountryFormat, unlike a fixed list that would
+// drift out of sync with countryFormats.
+func SupportedCountryCodes() []string {
+	codes := make([]string, 0, len(countryFormats))
+	for code := range countryFormats {
+		codes = append(codes, code)
 	}
-)
+	slices.Sort(codes)
+	return codes
+}
 
+// ParseAddress parses a raw VIES address for countryCode using the
+// CLDR-style CountryFormat registered for it. It walks the format's line
+// templates against the address, using the literal separators in the
+// template (and, where present, the country's postal-code regexp) as
+// anchors for splitting the street, zip and city tokens.
+//
+// If the built-in parser can't handle the address (ErrorUnsupportedCountryCode
+// or ErrorInvalidOption) and a fallback parser has been registered with
+// SetFallbackParser, ParseAddress transparently retries the address with it.
 func ParseAddress(countryCode, address string, config ...Config) (ParsedAddress, error) {
 	if len(countryCode) == 0 {
 		return ParsedAddress{}, ErrorMissingCountryCode
@@ -94,95 +75,152 @@ func ParseAddress(countryCode, address string, config ...Config) (ParsedAddress,
 	}
 	countryCode = strings.TrimSpace(countryCode)
 	address = strings.TrimSpace(address)
-	newlinesCount := strings.Count(address, "\n")
-	if !slices.Contains(SupportedCountryCodes, countryCode) {
-		return ParsedAddress{}, ErrorUnsupportedCountryCode
+	parsed, err := parseAddressBuiltin(countryCode, address, config)
+	if (err == ErrorUnsupportedCountryCode || err == ErrorInvalidOption) && fallbackParser != nil {
+		return fallbackParser.Parse(countryCode, address)
 	}
-	if newlinesCount == 1 && slices.Contains([]string{nl, be, fr, fi, at, pl, dk}, countryCode) {
-		parts := strings.Split(address, "\n")
-		locationParts := strings.Split(parts[1], " ")
-		return ParsedAddress{
-			Street: strings.TrimSpace(parts[0]),
-			Zip:    strings.TrimSpace(locationParts[0]),
-			City:   strings.TrimSpace(locationParts[1]),
-		}, nil
+	return parsed, err
+}
+
+// parseAddressBuiltin is the country-specific parser described above, with
+// countryCode and address already validated and trimmed.
+func parseAddressBuiltin(countryCode, address string, config []Config) (ParsedAddress, error) {
+	format, ok := GetCountryFormat(countryCode)
+	if !ok {
+		return ParsedAddress{}, ErrorUnsupportedCountryCode
 	}
-	if newlinesCount == 0 && slices.Contains([]string{si, hr}, countryCode) {
-		parts := strings.Split(address, ",")
-		street := strings.TrimSpace(parts[0])
-		if len(parts) == 3 {
-			street = street + ", " + strings.TrimSpace(parts[1])
-		}
-		locationParts := strings.Split(parts[len(parts)-1], " ")
-		return ParsedAddress{
-			Street: street,
-			Zip:    locationParts[0],
-			City:   locationParts[1],
-		}, nil
+	addressLines := strings.Split(address, "\n")
+	templates, ok := format.Formats[len(addressLines)-1]
+	if !ok {
+		return ParsedAddress{}, ErrorInvalidOption
 	}
-	if countryCode == sk {
-		if newlinesCount == 1 {
-			var city, zip string
-			parts := strings.Split(address, "\n")
-			street := strings.TrimSpace(parts[0])
-			if parts[1] != "Slovensko" {
-				locationParts := strings.Split(parts[len(parts)-1], " ")
-				zip = locationParts[0]
-				city = locationParts[1]
+	var lastErr error = ErrorInvalidOption
+	for _, template := range templates {
+		parsed, err := parseTemplate(template, addressLines, format)
+		if err == nil {
+			if format.NormalizeCity != nil {
+				parsed.City = format.NormalizeCity(parsed.City)
 			}
-			if parts[1] == "Slovensko" {
-				locationParts := strings.Split(parts[0], " ")
-				zip = locationParts[0]
-				city = locationParts[1]
-				street = ""
+			cfg := resolveConfig(config)
+			if countryCode == el && !cfg.IgnoreGreek {
+				parsed.Street = TransliterateGreek(parsed.Street)
+				parsed.City = TransliterateGreek(parsed.City)
 			}
-			city = strings.Replace(city, "mestská časť ", "", 1)
-			city = strings.Replace(city, "m. č. ", "", 1)
-			return ParsedAddress{
-				Street: strings.TrimSpace(street),
-				City:   strings.TrimSpace(city),
-				Zip:    strings.TrimSpace(zip),
-			}, nil
+			if cfg.NormalizeCity {
+				if suggestion, distance, ok := SuggestCity(countryCode, parsed.City); ok && distance <= cfg.CityDistanceThreshold {
+					parsed.City = suggestion
+				}
+			}
+			return parsed, nil
 		}
-		if newlinesCount == 2 {
-			var city, zip string
-			parts := strings.Split(address, "\n")
-			street := strings.TrimSpace(parts[0])
-			locationParts := strings.Split(parts[len(parts)-1], " ")
-			zip = locationParts[0]
-			city = locationParts[1]
-			city = strings.Replace(city, "mestská časť ", "", 1)
-			city = strings.Replace(city, "m. č. ", "", 1)
-			return ParsedAddress{
-				Street: strings.TrimSpace(street),
-				City:   strings.TrimSpace(city),
-				Zip:    strings.TrimSpace(zip),
-			}, nil
+		lastErr = err
+	}
+	return ParsedAddress{}, lastErr
+}
+
+// resolveConfig returns the single Config passed to ParseAddress, or the
+// zero value when none was given.
+func resolveConfig(config []Config) Config {
+	if len(config) == 0 {
+		return Config{}
+	}
+	return config[0]
+}
+
+// parseTemplate walks template, a "\n"-joined sequence of line templates,
+// against addressLines, filling in the %S, %Z and %C tokens it finds.
+func parseTemplate(template string, addressLines []string, format CountryFormat) (ParsedAddress, error) {
+	templateLines := strings.Split(template, "\n")
+	if len(templateLines) != len(addressLines) {
+		return ParsedAddress{}, ErrorInvalidOption
+	}
+	tokens := map[string]string{}
+	for i, templateLine := range templateLines {
+		if err := parseTemplateLine(templateLine, strings.TrimSpace(addressLines[i]), format.PostalCodeRegexp, tokens); err != nil {
+			return ParsedAddress{}, err
 		}
 	}
-	if countryCode == cz {
-		if newlinesCount == 1 {
-			parts := strings.Split(address, "\n")
-			street := strings.TrimSpace(parts[0])
-			lastParts := strings.Split(strings.TrimSpace(parts[len(parts)-1]), " ")
-			return ParsedAddress{
-				Street: street,
-				City:   strings.TrimSpace(strings.Join(lastParts[len(lastParts)-2:len(lastParts)-1], "")),
-				Zip:    strings.TrimSpace(strings.Join(lastParts[:len(lastParts)-2], "")),
-			}, nil
+	return ParsedAddress{
+		Street: strings.TrimSpace(tokens["%S"]),
+		City:   strings.TrimSpace(tokens["%C"]),
+		Zip:    strings.TrimSpace(tokens["%Z"]),
+	}, nil
+}
+
+// parseTemplateLine matches a single line template (e.g. "%Z %C" or
+// "%S, %Z %C") against line, storing each captured token in tokens. A
+// template line with no token must match line literally, which is how a
+// trailing country name (e.g. Slovak "Slovensko") is recognised. A bare
+// "%Z" line is anchored on the postal-code regexp too, so a trailing
+// country name on the same line (e.g. Czech "Czech Republic") is
+// discarded instead of being folded into the zip.
+func parseTemplateLine(templateLine, line string, postalCodeRegexp *regexp.Regexp, tokens map[string]string) error {
+	matches := templateTokenPattern.FindAllStringIndex(templateLine, -1)
+	switch len(matches) {
+	case 0:
+		if !strings.EqualFold(templateLine, line) {
+			return ErrorInvalidOption
+		}
+		return nil
+	case 1:
+		token := tokenAt(templateLine, matches[0])
+		if token == "%Z" && postalCodeRegexp != nil {
+			loc := postalCodeRegexp.FindStringIndex(line)
+			if loc == nil || loc[0] != 0 {
+				return ErrorInvalidPostalCode
+			}
+			tokens[token] = line[:loc[1]]
+			return nil
 		}
-		if newlinesCount == 2 {
-			parts := strings.Split(address, "\n")
-			lastParts := strings.Split(strings.TrimSpace(parts[len(parts)-1]), " ")
-			return ParsedAddress{
-				Street: strings.TrimSpace(parts[0]),
-				City:   strings.TrimSpace(parts[1]),
-				Zip:    strings.TrimSpace(strings.Join(lastParts[:len(lastParts)-2], "")),
-			}, nil
+		tokens[token] = line
+		return nil
+	case 2:
+		return parseTokenPair(templateLine, line, matches[0], matches[1], postalCodeRegexp, tokens)
+	case 3:
+		separator := templateLine[matches[0][1]:matches[1][0]]
+		parts := strings.Split(line, separator)
+		if len(parts) < 2 {
+			return ErrorInvalidOption
 		}
-		return ParsedAddress{}, ErrorInvalidOption
+		// Extra parts (e.g. a street address containing its own comma)
+		// are folded into the first token.
+		extra := len(parts) - 2
+		tokens[tokenAt(templateLine, matches[0])] = strings.Join(parts[:extra+1], separator)
+		return parseTokenPair(templateLine, parts[extra+1], matches[1], matches[2], postalCodeRegexp, tokens)
+	default:
+		return ErrorInvalidOption
 	}
-	return ParsedAddress{}, nil
+}
+
+// parseTokenPair splits line between two adjacent template tokens. When
+// the pair is %Z followed by %C and a postal-code regexp is available, the
+// regexp anchors the split so that a zip containing the line's separator
+// (e.g. Czech "120 00") or a multi-word city (e.g. "Praha 4") is handled
+// correctly; otherwise the line is split on the literal separator between
+// the two tokens.
+func parseTokenPair(templateLine, line string, first, second []int, postalCodeRegexp *regexp.Regexp, tokens map[string]string) error {
+	separator := templateLine[first[1]:second[0]]
+	firstToken, secondToken := tokenAt(templateLine, first), tokenAt(templateLine, second)
+	if firstToken == "%Z" && secondToken == "%C" && postalCodeRegexp != nil {
+		loc := postalCodeRegexp.FindStringIndex(line)
+		if loc == nil {
+			return ErrorInvalidPostalCode
+		}
+		tokens["%Z"] = line[:loc[1]]
+		tokens["%C"] = strings.TrimPrefix(line[loc[1]:], separator)
+		return nil
+	}
+	parts := strings.SplitN(line, separator, 2)
+	if len(parts) != 2 {
+		return ErrorInvalidOption
+	}
+	tokens[firstToken] = parts[0]
+	tokens[secondToken] = parts[1]
+	return nil
+}
+
+func tokenAt(s string, match []int) string {
+	return s[match[0]:match[1]]
 }
 
 func MustParseAddress(countryCode, address string) ParsedAddress {